@@ -129,6 +129,100 @@ func (d *durationField) UnmarshalYAML(node *yaml.Node) error {
 	return nil
 }
 
+var byteSizeFieldPattern = regexp.MustCompile(`^(\d+)(B|KB|MB|GB)$`)
+
+type byteSizeField int64
+
+func (s *byteSizeField) UnmarshalYAML(node *yaml.Node) error {
+	var value string
+
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+
+	matches := byteSizeFieldPattern.FindStringSubmatch(value)
+
+	if len(matches) != 3 {
+		return fmt.Errorf("invalid size format: %s", value)
+	}
+
+	size, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	if size == 0 {
+		return fmt.Errorf("size must be greater than 0: %s", value)
+	}
+
+	switch matches[2] {
+	case "B":
+		*s = byteSizeField(size)
+	case "KB":
+		*s = byteSizeField(size * 1024)
+	case "MB":
+		*s = byteSizeField(size * 1024 * 1024)
+	case "GB":
+		*s = byteSizeField(size * 1024 * 1024 * 1024)
+	}
+
+	return nil
+}
+
+var timeRangeFieldPattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})$`)
+
+// A daily time range such as 09:00-17:00, the end is exclusive and
+// ranges where the start is after the end cross midnight, e.g. 22:00-06:00
+type timeRangeField struct {
+	StartMinute int
+	EndMinute   int
+}
+
+func (r *timeRangeField) UnmarshalYAML(node *yaml.Node) error {
+	var value string
+
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+
+	matches := timeRangeFieldPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if len(matches) != 5 {
+		return fmt.Errorf("invalid time range format, expected HH:MM-HH:MM: %s", value)
+	}
+
+	minutes := make([]int, 2)
+
+	for i := range minutes {
+		hours, _ := strconv.Atoi(matches[1+i*2])
+		mins, _ := strconv.Atoi(matches[2+i*2])
+
+		if hours > 23 || mins > 59 {
+			return fmt.Errorf("invalid time in time range: %s", value)
+		}
+
+		minutes[i] = hours*60 + mins
+	}
+
+	if minutes[0] == minutes[1] {
+		return fmt.Errorf("start and end of time range cannot be the same: %s", value)
+	}
+
+	r.StartMinute = minutes[0]
+	r.EndMinute = minutes[1]
+
+	return nil
+}
+
+func (r *timeRangeField) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+
+	if r.StartMinute < r.EndMinute {
+		return minute >= r.StartMinute && minute < r.EndMinute
+	}
+
+	return minute >= r.StartMinute || minute < r.EndMinute
+}
+
 type customIconField struct {
 	URL        template.URL
 	AutoInvert bool
@@ -0,0 +1,79 @@
+package glance
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTimeRangeField(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		value    string
+		time     time.Time
+		expected bool
+	}{
+		{"09:00-17:00", at(9, 0), true},
+		{"09:00-17:00", at(12, 30), true},
+		{"09:00-17:00", at(16, 59), true},
+		{"09:00-17:00", at(17, 0), false},
+		{"09:00-17:00", at(8, 59), false},
+		{"22:00-06:00", at(23, 0), true},
+		{"22:00-06:00", at(0, 0), true},
+		{"22:00-06:00", at(5, 59), true},
+		{"22:00-06:00", at(6, 0), false},
+		{"22:00-06:00", at(12, 0), false},
+	}
+
+	for _, test := range tests {
+		var field timeRangeField
+		if err := yaml.Unmarshal([]byte(test.value), &field); err != nil {
+			t.Fatalf("Failed to parse %s: %v", test.value, err)
+		}
+
+		if got := field.contains(test.time); got != test.expected {
+			t.Errorf("%s contains %s: expected %t, got %t", test.value, test.time.Format("15:04"), test.expected, got)
+		}
+	}
+
+	for _, invalid := range []string{"9-17", "09:00", "24:00-06:00", "09:60-10:00", "09:00-09:00"} {
+		var field timeRangeField
+		if err := yaml.Unmarshal([]byte(invalid), &field); err == nil {
+			t.Errorf("Expected %s to be an invalid time range", invalid)
+		}
+	}
+}
+
+func TestByteSizeField(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected byteSizeField
+	}{
+		{"512B", 512},
+		{"64KB", 64 * 1024},
+		{"8MB", 8 * 1024 * 1024},
+		{"1GB", 1024 * 1024 * 1024},
+	}
+
+	for _, test := range tests {
+		var field byteSizeField
+		if err := yaml.Unmarshal([]byte(test.value), &field); err != nil {
+			t.Fatalf("Failed to parse %s: %v", test.value, err)
+		}
+
+		if field != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.value, test.expected, field)
+		}
+	}
+
+	for _, value := range []string{"0MB", "8", "8mb", "1.5MB", "-1MB"} {
+		var field byteSizeField
+		if err := yaml.Unmarshal([]byte(value), &field); err == nil {
+			t.Errorf("Expected an error for %s", value)
+		}
+	}
+}
@@ -29,11 +29,13 @@ const (
 
 type config struct {
 	Server struct {
-		Host       string `yaml:"host"`
-		Port       uint16 `yaml:"port"`
-		Proxied    bool   `yaml:"proxied"`
-		AssetsPath string `yaml:"assets-path"`
-		BaseURL    string `yaml:"base-url"`
+		Host                string        `yaml:"host"`
+		Port                uint16        `yaml:"port"`
+		Proxied             bool          `yaml:"proxied"`
+		AssetsPath          string        `yaml:"assets-path"`
+		BaseURL             string        `yaml:"base-url"`
+		LogFormat           string        `yaml:"log-format"`
+		MaxResponseBodySize byteSizeField `yaml:"max-response-body-size"`
 	} `yaml:"server"`
 
 	Auth struct {
@@ -100,39 +102,85 @@ func newConfigFromYAML(contents []byte) (*config, error) {
 	config := &config{}
 	config.Server.Port = 8080
 
+	// collect as many errors as possible rather than stopping at the first one
+	// so that every problem can be fixed at once
+	var errs configErrors
+
 	err = yaml.Unmarshal(contents, config)
 	if err != nil {
-		return nil, err
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, err
+		}
+
+		// the decoder carries on after type errors, so the rest of the config can still be checked
+		for i := range typeErr.Errors {
+			errs = append(errs, errors.New(typeErr.Errors[i]))
+		}
 	}
 
 	if err = isConfigStateValid(config); err != nil {
-		return nil, err
+		errs = append(errs, err)
 	}
 
 	for p := range config.Pages {
 		for w := range config.Pages[p].HeadWidgets {
 			if err := config.Pages[p].HeadWidgets[w].initialize(); err != nil {
-				return nil, formatWidgetInitError(err, config.Pages[p].HeadWidgets[w])
+				errs = appendWidgetInitError(errs, err, config.Pages[p].HeadWidgets[w])
 			}
 		}
 
 		for c := range config.Pages[p].Columns {
 			for w := range config.Pages[p].Columns[c].Widgets {
 				if err := config.Pages[p].Columns[c].Widgets[w].initialize(); err != nil {
-					return nil, formatWidgetInitError(err, config.Pages[p].Columns[c].Widgets[w])
+					errs = appendWidgetInitError(errs, err, config.Pages[p].Columns[c].Widgets[w])
 				}
 			}
 		}
 	}
 
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
 	return config, nil
 }
 
+type configErrors []error
+
+func (errs configErrors) Error() string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "found %d problems:", len(errs))
+
+	for _, err := range errs {
+		builder.WriteString("\n - ")
+		builder.WriteString(err.Error())
+	}
+
+	return builder.String()
+}
+
+func (errs configErrors) Unwrap() []error {
+	return errs
+}
+
 var envVariableNamePattern = regexp.MustCompile(`^[A-Z0-9_]+$`)
-var configVariablePattern = regexp.MustCompile(`(^|.)\$\{(?:([a-zA-Z]+):)?([a-zA-Z0-9_-]+)\}`)
+
+// The first alternative keeps names such as ${secret:-name} from being parsed as a
+// variable named "secret" with a default value, since those names were valid before
+// default values were supported. Default values can't span multiple lines so that a
+// missing closing brace doesn't swallow the rest of the config.
+var configVariablePattern = regexp.MustCompile(
+	`(^|.)\$\{(?:(secret|readFileFromEnv):(-[a-zA-Z0-9_-]*)|(?:([a-zA-Z]+):)?([a-zA-Z0-9_][a-zA-Z0-9_-]*)(?::-([^}\n]*))?)\}`,
+)
 
 // Parses variables defined in the config such as:
 // ${API_KEY} 				            - gets replaced with the value of the API_KEY environment variable
+// ${API_KEY:-default} 			        - same as above but uses "default" if API_KEY is not set
 // \${API_KEY} 					        - escaped, gets used as is without the \ in the config
 // ${secret:api_key} 			        - value gets loaded from /run/secrets/api_key
 // ${readFileFromEnv:PATH_TO_SECRET}    - value gets loaded from the file path specified in the environment variable PATH_TO_SECRET
@@ -148,7 +196,7 @@ func parseConfigVariables(contents []byte) ([]byte, error) {
 		}
 
 		groups := configVariablePattern.FindSubmatch(match)
-		if len(groups) != 4 {
+		if len(groups) != 7 {
 			// we can't handle this match, this shouldn't happen unless the number of groups
 			// in the regex has been changed without updating the below code
 			return match
@@ -163,10 +211,22 @@ func parseConfigVariables(contents []byte) ([]byte, error) {
 			}
 		}
 
-		typeAsString, variableName := string(groups[2]), string(groups[3])
+		typeAsString, variableName := string(groups[4]), string(groups[5])
+		if groups[2] != nil {
+			typeAsString, variableName = string(groups[2]), string(groups[3])
+		}
+
 		variableType := ternary(typeAsString == "", configVarTypeEnv, typeAsString)
 
-		parsedValue, returnOriginal, localErr := parseConfigVariableOfType(variableType, variableName)
+		// groups[6] is nil when no default was specified and empty but non-nil
+		// for ${NAME:-}, which is a valid way of defaulting to an empty string
+		var defaultValue *string
+		if groups[6] != nil {
+			value := string(groups[6])
+			defaultValue = &value
+		}
+
+		parsedValue, returnOriginal, localErr := parseConfigVariableOfType(variableType, variableName, defaultValue)
 		if localErr != nil {
 			err = fmt.Errorf("parsing variable: %v", localErr)
 			return nil
@@ -186,8 +246,9 @@ func parseConfigVariables(contents []byte) ([]byte, error) {
 	return replaced, nil
 }
 
-// When the bool return value is true, it indicates that the caller should use the original value
-func parseConfigVariableOfType(variableType, variableName string) (string, bool, error) {
+// When the bool return value is true, it indicates that the caller should use the original value.
+// Default values are only supported for environment variables
+func parseConfigVariableOfType(variableType, variableName string, defaultValue *string) (string, bool, error) {
 	switch variableType {
 	case configVarTypeEnv:
 		if !envVariableNamePattern.MatchString(variableName) {
@@ -196,11 +257,19 @@ func parseConfigVariableOfType(variableType, variableName string) (string, bool,
 
 		v, found := os.LookupEnv(variableName)
 		if !found {
+			if defaultValue != nil {
+				return *defaultValue, false, nil
+			}
+
 			return "", false, fmt.Errorf("environment variable %s not found", variableName)
 		}
 
 		return v, false, nil
 	case configVarTypeSecret:
+		if defaultValue != nil {
+			return "", false, fmt.Errorf("secret %s: default values are only supported for environment variables", variableName)
+		}
+
 		secretPath := filepath.Join("/run/secrets", variableName)
 		secret, err := os.ReadFile(secretPath)
 		if err != nil {
@@ -209,6 +278,10 @@ func parseConfigVariableOfType(variableType, variableName string) (string, bool,
 
 		return strings.TrimSpace(string(secret)), false, nil
 	case configVarTypeFileFromEnv:
+		if defaultValue != nil {
+			return "", false, fmt.Errorf("readFileFromEnv: %s: default values are only supported for environment variables", variableName)
+		}
+
 		if !envVariableNamePattern.MatchString(variableName) {
 			return "", true, nil
 		}
@@ -237,6 +310,20 @@ func formatWidgetInitError(err error, w widget) error {
 	return fmt.Errorf("%s widget: %v", w.GetType(), err)
 }
 
+// Errors coming from widgets within containers get flattened so that each one is reported on its own
+func appendWidgetInitError(errs configErrors, err error, w widget) configErrors {
+	var nested configErrors
+	if errors.As(err, &nested) {
+		for i := range nested {
+			errs = append(errs, formatWidgetInitError(nested[i], w))
+		}
+
+		return errs
+	}
+
+	return append(errs, formatWidgetInitError(err, w))
+}
+
 var configIncludePattern = regexp.MustCompile(`(?m)^([ \t]*)(?:-[ \t]*)?(?:!|\$)include:[ \t]*(.+)$`)
 
 func parseYAMLIncludes(mainFilePath string) ([]byte, map[string]struct{}, error) {
@@ -477,6 +564,10 @@ func isConfigStateValid(config *config) error {
 		}
 	}
 
+	if config.Server.LogFormat != "" && config.Server.LogFormat != "text" && config.Server.LogFormat != "json" {
+		return fmt.Errorf("server log-format can only be either text or json")
+	}
+
 	if config.Server.AssetsPath != "" {
 		if _, err := os.Stat(config.Server.AssetsPath); os.IsNotExist(err) {
 			return fmt.Errorf("assets directory does not exist: %s", config.Server.AssetsPath)
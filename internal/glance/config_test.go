@@ -0,0 +1,131 @@
+package glance
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfigVariables(t *testing.T) {
+	t.Setenv("GLANCE_TEST_HOST", "localhost")
+	t.Setenv("GLANCE_TEST_EMPTY", "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"host: ${GLANCE_TEST_HOST}", "host: localhost"},
+		{"url: http://${GLANCE_TEST_HOST}:8080/", "url: http://localhost:8080/"},
+		{"host: ${GLANCE_TEST_HOST:-example.com}", "host: localhost"},
+		{"host: ${GLANCE_TEST_MISSING:-example.com}", "host: example.com"},
+		{"host: ${GLANCE_TEST_MISSING:-}", "host: "},
+		{"host: ${GLANCE_TEST_EMPTY:-example.com}", "host: "},
+		{"port: ${GLANCE_TEST_MISSING:-8080}", "port: 8080"},
+		{"escaped: \\${GLANCE_TEST_MISSING:-example.com}", "escaped: ${GLANCE_TEST_MISSING:-example.com}"},
+		{"lowercase: ${not_an_env_var}", "lowercase: ${not_an_env_var}"},
+		{"a: ${GLANCE_TEST_MISSING:-x\nb: }", "a: ${GLANCE_TEST_MISSING:-x\nb: }"},
+	}
+
+	for _, test := range tests {
+		parsed, err := parseConfigVariables([]byte(test.input))
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", test.input, err)
+			continue
+		}
+
+		if string(parsed) != test.expected {
+			t.Errorf("Expected %q for %q, got %q", test.expected, test.input, string(parsed))
+		}
+	}
+
+	if _, err := parseConfigVariables([]byte("host: ${GLANCE_TEST_MISSING}")); err == nil {
+		t.Error("Expected an error for a missing environment variable without a default")
+	}
+
+	for _, input := range []string{"token: ${secret:api_key:-x}", "token: ${readFileFromEnv:TOKEN_FILE:-x}"} {
+		if _, err := parseConfigVariables([]byte(input)); err == nil {
+			t.Errorf("Expected an error for a default value on a non-env variable: %s", input)
+		}
+	}
+
+	// names starting with a dash are read as names rather than as default values
+	if _, err := parseConfigVariables([]byte("token: ${secret:-api_key}")); err == nil || !strings.Contains(err.Error(), "-api_key") {
+		t.Errorf("Expected ${secret:-api_key} to read the secret file -api_key, got %v", err)
+	}
+
+	if parsed, err := parseConfigVariables([]byte("token: ${readFileFromEnv:-X}")); err != nil || string(parsed) != "token: ${readFileFromEnv:-X}" {
+		t.Errorf("Expected ${readFileFromEnv:-X} to be left as is, got %q, %v", parsed, err)
+	}
+}
+
+func TestNewConfigFromYAMLReportsAllWidgetErrors(t *testing.T) {
+	_, err := newConfigFromYAML([]byte(`
+pages:
+  - name: Home
+    columns:
+      - size: full
+        widgets:
+          - type: iframe
+          - type: search
+            bangs:
+              - title: YouTube
+                url: https://www.youtube.com/results?search_query={QUERY}
+      - size: small
+        widgets:
+          - type: iframe
+            source: https://example.com
+          - type: split-column
+            widgets:
+              - type: iframe
+              - type: group
+                widgets:
+                  - type: iframe
+                  - type: iframe
+                    source: ":invalid"
+`))
+
+	if err == nil {
+		t.Fatal("Expected config with invalid widgets to fail")
+	}
+
+	expected := "found 5 problems:" +
+		"\n - iframe widget: source is required" +
+		"\n - search widget: search bang #1 has no shortcut" +
+		"\n - split-column widget: iframe widget: source is required" +
+		"\n - split-column widget: group widget: iframe widget: source is required" +
+		"\n - split-column widget: group widget: iframe widget: parsing URL: parse \":invalid\": missing protocol scheme"
+	if err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%s", expected, err.Error())
+	}
+}
+
+func TestNewConfigFromYAMLReportsDecodingAndStateErrorsWithWidgetErrors(t *testing.T) {
+	_, err := newConfigFromYAML([]byte(`
+pages:
+  - name: Home
+    width: huge
+    columns:
+      - size: full
+        widgets:
+          - type: iframe
+          - type: rss
+            cache: abc
+          - type: group
+            widgets:
+              - type: iframe
+                source: https://example.com
+                cache: xyz
+`))
+
+	if err == nil {
+		t.Fatal("Expected invalid config to fail")
+	}
+
+	expected := "found 4 problems:" +
+		"\n - line 9: rss widget: invalid duration format: abc" +
+		"\n - line 13: iframe widget: invalid duration format: xyz" +
+		"\n - page 1: width can only be either wide or slim" +
+		"\n - iframe widget: source is required"
+	if err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%s", expected, err.Error())
+	}
+}
@@ -227,6 +227,12 @@ func newApplication(c *config) (*application, error) {
 	}
 	app.parsedManifest = []byte(manifest)
 
+	maxResponseBodySize.Store(ternary(
+		config.Server.MaxResponseBodySize > 0,
+		int64(config.Server.MaxResponseBodySize),
+		defaultMaxResponseBodySize,
+	))
+
 	return app, nil
 }
 
@@ -246,7 +252,7 @@ func (p *page) updateOutdatedWidgets() {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			widget.update(context)
+			updateWidgetWithWatchdog(context, widget)
 		}()
 	}
 
@@ -261,7 +267,7 @@ func (p *page) updateOutdatedWidgets() {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				widget.update(context)
+				updateWidgetWithWatchdog(context, widget)
 			}()
 		}
 	}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 
 	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
 )
 
 var buildVersion = "dev"
@@ -99,6 +101,8 @@ func serveApp(configPath string) error {
 	var stopServer func() error
 
 	onChange := func(newContents []byte) {
+		setLogFormatFromConfig(newContents)
+
 		if stopServer != nil {
 			log.Println("Config file changed, reloading...")
 		}
@@ -160,6 +164,8 @@ func serveApp(configPath string) error {
 	} else {
 		log.Printf("Error starting file watcher, config file changes will require a manual restart. (%v)", err)
 
+		setLogFormatFromConfig(configContents)
+
 		config, err := newConfigFromYAML(configContents)
 		if err != nil {
 			return fmt.Errorf("validating config file: %w", err)
@@ -180,6 +186,50 @@ func serveApp(configPath string) error {
 	return nil
 }
 
+var (
+	defaultLogWriter  = log.Writer()
+	defaultLogFlags   = log.Flags()
+	defaultSlogLogger = slog.Default()
+)
+
+// Setting a non-default slog logger also redirects the output of the log package
+// to its handler, so both end up formatted the same way
+func setLogFormat(format string) {
+	if format == "json" {
+		slog.SetDefault(slog.New(slog.NewJSONHandler(defaultLogWriter, nil)))
+		return
+	}
+
+	// the default slog handler writes through the log package, so its output
+	// needs to be restored before the default logger is, otherwise it would
+	// end up writing to the previously set handler
+	log.SetOutput(defaultLogWriter)
+	log.SetFlags(defaultLogFlags)
+	slog.SetDefault(defaultSlogLogger)
+}
+
+// Applied before the config gets parsed in full so that its errors and the warnings
+// logged while initializing widgets are formatted the same way as everything else.
+// Any problems are left for newConfigFromYAML to report.
+func setLogFormatFromConfig(contents []byte) {
+	contents, err := parseConfigVariables(contents)
+	if err != nil {
+		return
+	}
+
+	var partialConfig struct {
+		Server struct {
+			LogFormat string `yaml:"log-format"`
+		} `yaml:"server"`
+	}
+
+	if err := yaml.Unmarshal(contents, &partialConfig); err != nil {
+		return
+	}
+
+	setLogFormat(partialConfig.Server.LogFormat)
+}
+
 func serveUpdateNoticeIfConfigLocationNotMigrated(configPath string) bool {
 	if !isRunningInsideDockerContainer() {
 		return false
@@ -0,0 +1,76 @@
+package glance
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetLogFormat(t *testing.T) {
+	var output bytes.Buffer
+
+	previousWriter := defaultLogWriter
+	defaultLogWriter = &output
+	defer func() {
+		defaultLogWriter = previousWriter
+		setLogFormat("text")
+	}()
+
+	setLogFormat("json")
+	slog.Error("Failed to fetch", "widget", "videos")
+	log.Printf("Starting server")
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %q", len(lines), output.String())
+	}
+
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected log line to be JSON, got %q", line)
+		}
+	}
+
+	output.Reset()
+	setLogFormat("text")
+	log.Printf("Starting server")
+
+	if strings.HasPrefix(output.String(), "{") {
+		t.Fatalf("Expected text log output after switching back, got %q", output.String())
+	}
+
+	// warnings logged while the widgets get initialized should already use the configured format
+	output.Reset()
+	contents := []byte(`
+server:
+  log-format: json
+pages:
+  - name: Home
+    columns:
+      - size: full
+        widgets:
+          - type: videos
+            style: grid-cards
+            collapse-after: 3
+            channels:
+              - UCXuqSBlHAE6Xw-yeJA0Tunw
+`)
+
+	setLogFormatFromConfig(contents)
+	if _, err := newConfigFromYAML(contents); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(output.Bytes()), &entry); err != nil {
+		t.Fatalf("Expected the collapse warning to be logged as JSON, got %q", output.String())
+	}
+
+	if entry["level"] != "WARN" || !strings.Contains(entry["msg"].(string), "collapse-after") {
+		t.Fatalf("Expected the collapse warning, got %q", output.String())
+	}
+}
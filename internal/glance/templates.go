@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"math"
 	"strconv"
+	"strings"
 
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
@@ -14,6 +15,7 @@ var intl = message.NewPrinter(language.English)
 
 var globalTemplateFunctions = template.FuncMap{
 	"formatApproxNumber": formatApproxNumber,
+	"formatCount":        formatCount,
 	"formatNumber":       intl.Sprint,
 	"safeCSS": func(str string) template.CSS {
 		return template.CSS(str)
@@ -86,6 +88,57 @@ func formatApproxNumber(count int) string {
 	return strconv.FormatFloat(float64(count)/1_000_000, 'f', 1, 64) + "m"
 }
 
+type countUnit struct {
+	size   float64
+	suffix string
+}
+
+var (
+	countUnitsEnglish = []countUnit{{1e3, "K"}, {1e6, "M"}, {1e9, "B"}}
+	countUnitsChinese = []countUnit{{1e4, "万"}, {1e8, "亿"}}
+)
+
+// Formats a count compactly for the given locale, e.g. 12345 becomes 1.2万 for zh
+// and 12.3K for everything else. Values are rounded to one decimal place with
+// any trailing .0 removed
+func formatCount(n int64, locale string) string {
+	if n < 0 {
+		// computed this way since -n overflows for math.MinInt64
+		return "-" + formatCountMagnitude(uint64(-(n+1))+1, locale)
+	}
+
+	return formatCountMagnitude(uint64(n), locale)
+}
+
+func formatCountMagnitude(n uint64, locale string) string {
+	units := countUnitsEnglish
+	if strings.HasPrefix(strings.ToLower(locale), "zh") {
+		units = countUnitsChinese
+	}
+
+	if float64(n) < units[0].size {
+		return strconv.FormatUint(n, 10)
+	}
+
+	for i := len(units) - 1; i >= 0; i-- {
+		if float64(n) < units[i].size {
+			continue
+		}
+
+		value := math.Round(float64(n)/units[i].size*10) / 10
+
+		// rounding can push the value into the next unit, e.g. 999,999 would otherwise be 1000K
+		if i+1 < len(units) && value*units[i].size >= units[i+1].size {
+			value = math.Round(float64(n)/units[i+1].size*10) / 10
+			i++
+		}
+
+		return strconv.FormatFloat(value, 'f', -1, 64) + units[i].suffix
+	}
+
+	return strconv.FormatUint(n, 10)
+}
+
 func dynamicRelativeTimeAttrs(t interface{ Unix() int64 }) template.HTMLAttr {
 	return template.HTMLAttr(`data-dynamic-relative-time="` + strconv.FormatInt(t.Unix(), 10) + `"`)
 }
@@ -0,0 +1,40 @@
+package glance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		n        int64
+		locale   string
+		expected string
+	}{
+		{0, "zh", "0"},
+		{9999, "zh", "9999"},
+		{10000, "zh", "1万"},
+		{12345, "zh-CN", "1.2万"},
+		{99990000, "zh", "9999万"},
+		{99999999, "zh", "1亿"},
+		{100000000, "zh", "1亿"},
+		{250000000, "zh", "2.5亿"},
+		{999, "en", "999"},
+		{1000, "en", "1K"},
+		{9999, "en", "10K"},
+		{12345, "en", "12.3K"},
+		{999999, "en", "1M"},
+		{1500000, "", "1.5M"},
+		{2000000000, "en", "2B"},
+		{-12345, "en", "-12.3K"},
+		{math.MaxInt64, "en", "9223372036.9B"},
+		{math.MinInt64, "en", "-9223372036.9B"},
+		{math.MinInt64, "zh", "-92233720368.5亿"},
+	}
+
+	for _, test := range tests {
+		if got := formatCount(test.n, test.locale); got != test.expected {
+			t.Errorf("formatCount(%d, %q): expected %q, got %q", test.n, test.locale, test.expected, got)
+		}
+	}
+}
@@ -11,12 +11,18 @@ type containerWidgetBase struct {
 }
 
 func (widget *containerWidgetBase) _initializeWidgets() error {
+	var errs configErrors
+
 	for i := range widget.Widgets {
 		if err := widget.Widgets[i].initialize(); err != nil {
-			return formatWidgetInitError(err, widget.Widgets[i])
+			errs = appendWidgetInitError(errs, err, widget.Widgets[i])
 		}
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
 
@@ -39,6 +39,11 @@ func (widget *extensionWidget) initialize() error {
 		return fmt.Errorf("parsing URL: %v", err)
 	}
 
+	// paused widgets never get updated, which is where the HTML usually gets rendered
+	if widget.Paused {
+		widget.cachedHTML = widget.renderTemplate(widget, extensionWidgetTemplate)
+	}
+
 	return nil
 }
 
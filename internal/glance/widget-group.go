@@ -25,6 +25,8 @@ func (widget *groupWidget) initialize() error {
 			return errors.New("nested groups are not supported")
 		} else if widget.Widgets[i].GetType() == "split-column" {
 			return errors.New("split columns inside of groups are not supported")
+		} else if widget.Widgets[i].hasVisibleBetween() {
+			return errors.New("visible-between is not supported for widgets inside of groups")
 		}
 	}
 
@@ -44,6 +46,10 @@ func (widget *groupWidget) setProviders(providers *widgetProviders) {
 }
 
 func (widget *groupWidget) requiresUpdate(now *time.Time) bool {
+	if !widget.isActiveAt(*now) {
+		return false
+	}
+
 	return widget.containerWidgetBase._requiresUpdate(now)
 }
 
@@ -4,6 +4,9 @@ import (
 	"html/template"
 )
 
+// Only used to show the paused placeholder since the widget otherwise renders its source as is
+var htmlWidgetPausedTemplate = mustParseTemplate("widget-base.html")
+
 type htmlWidget struct {
 	widgetBase `yaml:",inline"`
 	Source     template.HTML `yaml:"source"`
@@ -16,5 +19,9 @@ func (widget *htmlWidget) initialize() error {
 }
 
 func (widget *htmlWidget) Render() template.HTML {
+	if widget.Paused {
+		return widget.renderTemplate(widget, htmlWidgetPausedTemplate)
+	}
+
 	return widget.Source
 }
@@ -37,6 +37,10 @@ func (widget *splitColumnWidget) setProviders(providers *widgetProviders) {
 }
 
 func (widget *splitColumnWidget) requiresUpdate(now *time.Time) bool {
+	if !widget.isActiveAt(*now) {
+		return false
+	}
+
 	return widget.containerWidgetBase._requiresUpdate(now)
 }
 
@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"math/rand/v2"
 	"net/http"
+	"runtime/debug"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -17,10 +19,22 @@ import (
 )
 
 var (
-	errNoContent      = errors.New("failed to retrieve any content")
-	errPartialContent = errors.New("failed to retrieve some of the content")
+	errNoContent        = errors.New("failed to retrieve any content")
+	errPartialContent   = errors.New("failed to retrieve some of the content")
+	errResponseTooLarge = errors.New("response body is too large")
 )
 
+// Upper limit for the size of response bodies read by the decode helpers below,
+// protects against misbehaving upstreams sending an unbounded amount of data.
+// Set from the server config when an application gets created.
+const defaultMaxResponseBodySize int64 = 8 * 1024 * 1024
+
+var maxResponseBodySize atomic.Int64
+
+func init() {
+	maxResponseBodySize.Store(defaultMaxResponseBodySize)
+}
+
 const defaultClientTimeout = 5 * time.Second
 
 var defaultHTTPClient = &http.Client{
@@ -59,6 +73,30 @@ func setBrowserUserAgentHeader(request *http.Request) {
 	request.Header.Set("User-Agent", getBrowserUserAgentHeader())
 }
 
+func readLimitedResponseBody(response *http.Response) ([]byte, error) {
+	limit := maxResponseBodySize.Load()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: exceeds limit of %d bytes", errResponseTooLarge, limit)
+	}
+
+	return body, nil
+}
+
+// Only reads as much of the body as is needed to include it in an error message,
+// 256 runes of at most 4 bytes each
+func readTruncatedResponseBody(response *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 256*4))
+	truncatedBody, _ := limitStringLength(string(body), 256)
+
+	return truncatedBody
+}
+
 func decodeJsonFromRequest[T any](client requestDoer, request *http.Request) (T, error) {
 	var result T
 
@@ -68,30 +106,45 @@ func decodeJsonFromRequest[T any](client requestDoer, request *http.Request) (T,
 	}
 	defer response.Body.Close()
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return result, err
-	}
-
 	if response.StatusCode != http.StatusOK {
-		truncatedBody, _ := limitStringLength(string(body), 256)
-
 		return result, fmt.Errorf(
 			"unexpected status code %d from %s, response: %s",
 			response.StatusCode,
 			request.URL,
-			truncatedBody,
+			readTruncatedResponseBody(response),
 		)
 	}
 
-	err = json.Unmarshal(body, &result)
+	body, err := readLimitedResponseBody(response)
 	if err != nil {
 		return result, err
 	}
 
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return result, annotateJsonDecodeError(err, len(body))
+	}
+
 	return result, nil
 }
 
+// The messages of syntax errors don't mention where in the body the error occurred,
+// which makes truncated responses look like generic failures
+func annotateJsonDecodeError(err error, bodyLength int) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("%w (at offset %d of %d bytes)", err, syntaxErr.Offset, bodyLength)
+	}
+
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("%w (at offset %d of %d bytes)", err, typeErr.Offset, bodyLength)
+	}
+
+	return err
+}
+
 func decodeJsonFromRequestTask[T any](client requestDoer) func(*http.Request) (T, error) {
 	return func(request *http.Request) (T, error) {
 		return decodeJsonFromRequest[T](client, request)
@@ -108,22 +161,20 @@ func decodeXmlFromRequest[T any](client requestDoer, request *http.Request) (T,
 	}
 	defer response.Body.Close()
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return result, err
-	}
-
 	if response.StatusCode != http.StatusOK {
-		truncatedBody, _ := limitStringLength(string(body), 256)
-
 		return result, fmt.Errorf(
 			"unexpected status code %d for %s, response: %s",
 			response.StatusCode,
 			request.URL,
-			truncatedBody,
+			readTruncatedResponseBody(response),
 		)
 	}
 
+	body, err := readLimitedResponseBody(response)
+	if err != nil {
+		return result, err
+	}
+
 	err = xml.Unmarshal(body, &result)
 	if err != nil {
 		return result, err
@@ -181,6 +232,19 @@ func newJob[I any, O any](task func(I) (O, error), data []I) *workerPoolJob[I, O
 	}
 }
 
+// Converts a panic within a task into an error for that task so that the
+// rest of the job can still complete
+func runWorkerPoolTask[I any, O any](task func(I) (O, error), input I) (output O, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Recovered from panic in worker pool task", "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+
+	return task(input)
+}
+
 func workerPoolDo[I any, O any](job *workerPoolJob[I, O]) ([]O, []error, error) {
 	results := make([]O, len(job.data))
 	errs := make([]error, len(job.data))
@@ -190,7 +254,7 @@ func workerPoolDo[I any, O any](job *workerPoolJob[I, O]) ([]O, []error, error)
 	}
 
 	if len(job.data) == 1 {
-		results[0], errs[0] = job.task(job.data[0])
+		results[0], errs[0] = runWorkerPoolTask(job.task, job.data[0])
 		return results, errs, nil
 	}
 
@@ -205,7 +269,7 @@ func workerPoolDo[I any, O any](job *workerPoolJob[I, O]) ([]O, []error, error)
 			defer wg.Done()
 
 			for t := range tasksQueue {
-				t.output, t.err = job.task(t.input)
+				t.output, t.err = runWorkerPoolTask(job.task, t.input)
 				resultsQueue <- t
 			}
 		}()
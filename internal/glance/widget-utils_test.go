@@ -0,0 +1,138 @@
+package glance
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type stubRequestDoer struct {
+	statusCode int
+	body       string
+}
+
+func (d *stubRequestDoer) Do(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: d.statusCode,
+		Body:       io.NopCloser(strings.NewReader(d.body)),
+	}, nil
+}
+
+func TestDecodeJsonFromRequestIncludesErrorOffset(t *testing.T) {
+	request, _ := http.NewRequest("GET", "http://localhost/", nil)
+	client := &stubRequestDoer{statusCode: http.StatusOK, body: `{"items": [{"title": "a"}, {"title": `}
+
+	_, err := decodeJsonFromRequest[map[string]any](client, request)
+	if err == nil {
+		t.Fatal("Expected an error when decoding truncated JSON")
+	}
+
+	if !strings.Contains(err.Error(), "at offset 37 of 37 bytes") {
+		t.Fatalf("Expected error to contain the offset, got: %v", err)
+	}
+}
+
+func TestDecodeJsonFromRequestLimitsBodySize(t *testing.T) {
+	previousMax := maxResponseBodySize.Load()
+	maxResponseBodySize.Store(16)
+	defer maxResponseBodySize.Store(previousMax)
+
+	request, _ := http.NewRequest("GET", "http://localhost/", nil)
+
+	_, err := decodeJsonFromRequest[map[string]any](
+		&stubRequestDoer{statusCode: http.StatusOK, body: `{"a":"12345678"}`},
+		request,
+	)
+	if err != nil {
+		t.Fatalf("Did not expect a body at the limit to be rejected, got: %v", err)
+	}
+
+	_, err = decodeJsonFromRequest[map[string]any](
+		&stubRequestDoer{statusCode: http.StatusOK, body: `{"title": "more than sixteen bytes"}`},
+		request,
+	)
+	if !errors.Is(err, errResponseTooLarge) {
+		t.Fatalf("Expected errResponseTooLarge, got: %v", err)
+	}
+
+	_, err = decodeJsonFromRequest[map[string]any](
+		&stubRequestDoer{statusCode: http.StatusBadGateway, body: "<html>" + strings.Repeat("a", 1000) + "</html>"},
+		request,
+	)
+	if errors.Is(err, errResponseTooLarge) || !strings.Contains(err.Error(), "unexpected status code 502") {
+		t.Fatalf("Expected an oversized error body to report the status code, got: %v", err)
+	}
+}
+
+func TestWorkerPoolDoRecoversFromPanics(t *testing.T) {
+	previousLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer slog.SetDefault(previousLogger)
+
+	task := func(n int) (int, error) {
+		if n == 2 {
+			var m map[string]int
+			m["boom"] = n
+		}
+
+		return n * 10, nil
+	}
+
+	for _, data := range [][]int{{1, 2, 3, 4}, {2}} {
+		results, errs, err := workerPoolDo(newJob(task, data).withWorkers(2))
+		if err != nil {
+			t.Fatalf("Unexpected job error: %v", err)
+		}
+
+		for i, n := range data {
+			if n == 2 {
+				if errs[i] == nil || !strings.Contains(errs[i].Error(), "panicked") {
+					t.Errorf("Expected task %d to report a panic, got: %v", i, errs[i])
+				}
+				continue
+			}
+
+			if errs[i] != nil || results[i] != n*10 {
+				t.Errorf("Expected task %d to succeed with %d, got %d (%v)", i, n*10, results[i], errs[i])
+			}
+		}
+	}
+}
+
+func TestMaxResponseBodySizeIsSetFromConfig(t *testing.T) {
+	defer maxResponseBodySize.Store(defaultMaxResponseBodySize)
+
+	newApp := func(server string) {
+		t.Helper()
+
+		config, err := newConfigFromYAML([]byte(server + `
+pages:
+  - name: Home
+    columns:
+      - size: full
+        widgets:
+          - type: html
+            source: <p>hello</p>
+`))
+		if err != nil {
+			t.Fatalf("Failed to parse config: %v", err)
+		}
+
+		if _, err := newApplication(config); err != nil {
+			t.Fatalf("Failed to create application: %v", err)
+		}
+	}
+
+	newApp("server:\n  max-response-body-size: 64KB\n")
+	if got := maxResponseBodySize.Load(); got != 64*1024 {
+		t.Errorf("Expected limit of %d bytes, got %d", 64*1024, got)
+	}
+
+	newApp("")
+	if got := maxResponseBodySize.Load(); got != defaultMaxResponseBodySize {
+		t.Errorf("Expected the default limit to be restored, got %d", got)
+	}
+}
@@ -40,6 +40,27 @@ func (widget *videosWidget) initialize() error {
 		widget.Limit = 25
 	}
 
+	// Each style supports at most one of the collapse properties, grid-cards uses rows,
+	// vertical-list uses items and horizontal-cards doesn't collapse at all
+	warnAboutCollapse := func(message string) {
+		slog.Warn("Videos widget: "+message, "title", widget.Title, "id", widget.ID)
+	}
+
+	switch widget.Style {
+	case "grid-cards":
+		if widget.CollapseAfter != 0 {
+			warnAboutCollapse("collapse-after has no effect with the grid-cards style, use collapse-after-rows instead")
+		}
+	case "vertical-list":
+		if widget.CollapseAfterRows != 0 {
+			warnAboutCollapse("collapse-after-rows has no effect with the vertical-list style, use collapse-after instead")
+		}
+	default:
+		if widget.CollapseAfter != 0 || widget.CollapseAfterRows != 0 {
+			warnAboutCollapse("collapse-after and collapse-after-rows have no effect with the horizontal-cards style")
+		}
+	}
+
 	if widget.CollapseAfterRows == 0 || widget.CollapseAfterRows < -1 {
 		widget.CollapseAfterRows = 4
 	}
@@ -130,8 +151,14 @@ type video struct {
 
 type videoList []video
 
+// Videos posted in the same second (common with batch uploads) are ordered by their
+// URL so that the order doesn't change between updates
 func (v videoList) sortByNewest() videoList {
-	sort.Slice(v, func(i, j int) bool {
+	sort.SliceStable(v, func(i, j int) bool {
+		if v[i].TimePosted.Equal(v[j].TimePosted) {
+			return v[i].Url < v[j].Url
+		}
+
 		return v[i].TimePosted.After(v[j].TimePosted)
 	})
 
@@ -0,0 +1,124 @@
+package glance
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVideoListSortByNewestIsDeterministic(t *testing.T) {
+	now := time.Now()
+
+	videos := videoList{
+		{Url: "c", TimePosted: now},
+		{Url: "old", TimePosted: now.Add(-time.Hour)},
+		{Url: "a", TimePosted: now},
+		{Url: "new", TimePosted: now.Add(time.Hour)},
+		{Url: "b", TimePosted: now},
+	}
+
+	expected := []string{"new", "a", "b", "c", "old"}
+
+	for range 3 {
+		videos.sortByNewest()
+
+		for i := range expected {
+			if videos[i].Url != expected[i] {
+				t.Fatalf("Expected video at index %d to be %s, got %s", i, expected[i], videos[i].Url)
+			}
+		}
+
+		// reverse to make sure the starting order doesn't affect the result
+		for i, j := 0, len(videos)-1; i < j; i, j = i+1, j-1 {
+			videos[i], videos[j] = videos[j], videos[i]
+		}
+	}
+}
+
+func TestVideosWidgetCollapseAfterResolution(t *testing.T) {
+	// both properties are set on the same widget, which logs a warning about the one not applying to the style
+	previousLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer slog.SetDefault(previousLogger)
+
+	tests := []struct {
+		value            int
+		expected         int
+		expectedRows     int
+		expectedRendered string
+	}{
+		{value: -1, expected: -1, expectedRows: -1, expectedRendered: `data-collapse-after="-1"`},
+		{value: 0, expected: 7, expectedRows: 4, expectedRendered: `data-collapse-after="7"`},
+		{value: 3, expected: 3, expectedRows: 3, expectedRendered: `data-collapse-after="3"`},
+		{value: -5, expected: 7, expectedRows: 4, expectedRendered: `data-collapse-after="7"`},
+	}
+
+	for _, test := range tests {
+		widget := &videosWidget{
+			Style:             "vertical-list",
+			CollapseAfter:     test.value,
+			CollapseAfterRows: test.value,
+		}
+
+		if err := widget.initialize(); err != nil {
+			t.Fatalf("Failed to initialize widget: %v", err)
+		}
+
+		if widget.CollapseAfter != test.expected {
+			t.Errorf("collapse-after %d: expected %d, got %d", test.value, test.expected, widget.CollapseAfter)
+		}
+
+		if widget.CollapseAfterRows != test.expectedRows {
+			t.Errorf("collapse-after-rows %d: expected %d, got %d", test.value, test.expectedRows, widget.CollapseAfterRows)
+		}
+
+		widget.withError(nil)
+		widget.Videos = videoList{{Title: "Video", Url: "https://www.youtube.com/watch?v=1"}}
+
+		if rendered := string(widget.Render()); !strings.Contains(rendered, test.expectedRendered) {
+			t.Errorf("collapse-after %d: expected rendered widget to contain %s", test.value, test.expectedRendered)
+		}
+	}
+}
+
+func TestVideosWidgetWarnsAboutInapplicableCollapseSettings(t *testing.T) {
+	var output bytes.Buffer
+
+	previousLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&output, nil)))
+	defer slog.SetDefault(previousLogger)
+
+	tests := []struct {
+		widget      videosWidget
+		expectsWarn bool
+	}{
+		{videosWidget{Style: "grid-cards", CollapseAfterRows: 2}, false},
+		{videosWidget{Style: "grid-cards", CollapseAfter: 5}, true},
+		{videosWidget{Style: "vertical-list", CollapseAfter: 5}, false},
+		{videosWidget{Style: "vertical-list", CollapseAfterRows: 2}, true},
+		{videosWidget{Style: "vertical-list"}, false},
+		{videosWidget{Style: "horizontal-cards", CollapseAfter: 5}, true},
+		{videosWidget{CollapseAfterRows: 2}, true},
+		{videosWidget{}, false},
+	}
+
+	for _, test := range tests {
+		output.Reset()
+
+		if err := test.widget.initialize(); err != nil {
+			t.Fatalf("Failed to initialize widget: %v", err)
+		}
+
+		if test.expectsWarn && !strings.Contains(output.String(), "title=Videos") {
+			t.Errorf("Expected warning to include the widget title, got %q", output.String())
+		}
+
+		if warned := output.Len() > 0; warned != test.expectsWarn {
+			t.Errorf("style %s, collapse-after %d, collapse-after-rows %d: expected warning %t, got %q",
+				test.widget.Style, test.widget.CollapseAfter, test.widget.CollapseAfterRows, test.expectsWarn, output.String())
+		}
+	}
+}
@@ -94,6 +94,9 @@ type widgets []widget
 
 func (w *widgets) UnmarshalYAML(node *yaml.Node) error {
 	var nodes []yaml.Node
+	// returned as a type error so that the decoder carries on with
+	// the rest of the config rather than stopping at the first widget
+	var problems []string
 
 	if err := node.Decode(&nodes); err != nil {
 		return err
@@ -114,12 +117,23 @@ func (w *widgets) UnmarshalYAML(node *yaml.Node) error {
 		}
 
 		if err = node.Decode(widget); err != nil {
-			return err
+			var typeErr *yaml.TypeError
+			if errors.As(err, &typeErr) {
+				problems = append(problems, typeErr.Errors...)
+			} else {
+				problems = append(problems, fmt.Sprintf("line %d: %s widget: %v", node.Line, meta.Type, err))
+			}
+
+			continue
 		}
 
 		*w = append(*w, widget)
 	}
 
+	if len(problems) > 0 {
+		return &yaml.TypeError{Errors: problems}
+	}
+
 	return nil
 }
 
@@ -128,6 +142,7 @@ type widget interface {
 	Render() template.HTML
 	GetType() string
 	GetID() uint64
+	IsVisible() bool
 
 	initialize() error
 	requiresUpdate(*time.Time) bool
@@ -136,6 +151,26 @@ type widget interface {
 	setID(uint64)
 	handleRequest(w http.ResponseWriter, r *http.Request)
 	setHideHeader(bool)
+	getSlowUpdateThreshold() time.Duration
+	hasVisibleBetween() bool
+}
+
+// Updates taking longer than this get logged so that a slow dashboard can be traced back to
+// a specific widget, can be changed per widget through the slow-update-threshold property
+const defaultSlowWidgetUpdateThreshold = 30 * time.Second
+
+func updateWidgetWithWatchdog(ctx context.Context, w widget) {
+	start := time.Now()
+	threshold := w.getSlowUpdateThreshold()
+	timer := time.AfterFunc(threshold, func() {
+		slog.Warn("Widget update is taking longer than expected", "type", w.GetType(), "id", w.GetID(), "threshold", threshold)
+	})
+
+	w.update(ctx)
+
+	if !timer.Stop() {
+		slog.Warn("Slow widget update finished", "type", w.GetType(), "id", w.GetID(), "duration", time.Since(start))
+	}
 }
 
 type cacheType int
@@ -154,6 +189,9 @@ type widgetBase struct {
 	TitleURL            string           `yaml:"title-url"`
 	HideHeader          bool             `yaml:"hide-header"`
 	CSSClass            string           `yaml:"css-class"`
+	Paused              bool             `yaml:"paused"`
+	VisibleBetween      *timeRangeField  `yaml:"visible-between"`
+	SlowUpdateThreshold durationField    `yaml:"slow-update-threshold"`
 	CustomCacheDuration durationField    `yaml:"cache"`
 	ContentAvailable    bool             `yaml:"-"`
 	WIP                 bool             `yaml:"-"`
@@ -170,8 +208,26 @@ type widgetProviders struct {
 	assetResolver func(string) string
 }
 
+// Paused widgets and widgets outside of their visible-between range don't get updated
+func (w *widgetBase) isActiveAt(t time.Time) bool {
+	return !w.Paused && w.isVisibleAt(t)
+}
+
+func (w *widgetBase) isVisibleAt(t time.Time) bool {
+	return w.VisibleBetween == nil || w.VisibleBetween.contains(t)
+}
+
+func (w *widgetBase) hasVisibleBetween() bool {
+	return w.VisibleBetween != nil
+}
+
+// Checked when rendering pages rather than within renderTemplate since some widgets cache their HTML
+func (w *widgetBase) IsVisible() bool {
+	return w.isVisibleAt(time.Now())
+}
+
 func (w *widgetBase) requiresUpdate(now *time.Time) bool {
-	if w.cacheType == cacheTypeInfinite {
+	if !w.isActiveAt(*now) || w.cacheType == cacheTypeInfinite {
 		return false
 	}
 
@@ -206,6 +262,14 @@ func (widget *widgetBase) handleRequest(w http.ResponseWriter, r *http.Request)
 	http.Error(w, "not implemented", http.StatusNotImplemented)
 }
 
+func (w *widgetBase) getSlowUpdateThreshold() time.Duration {
+	if w.SlowUpdateThreshold > 0 {
+		return time.Duration(w.SlowUpdateThreshold)
+	}
+
+	return defaultSlowWidgetUpdateThreshold
+}
+
 func (w *widgetBase) GetType() string {
 	return w.Type
 }
@@ -0,0 +1,289 @@
+package glance
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func newTestWidgetFromYAML(t *testing.T, contents string) widget {
+	t.Helper()
+
+	var w widgets
+	if err := yaml.Unmarshal([]byte(contents), &w); err != nil {
+		t.Fatalf("Failed to parse widget: %v", err)
+	}
+
+	if len(w) != 1 {
+		t.Fatalf("Expected exactly one widget, got %d", len(w))
+	}
+
+	if err := w[0].initialize(); err != nil {
+		t.Fatalf("Failed to initialize widget: %v", err)
+	}
+
+	return w[0]
+}
+
+func TestPausedWidgetDoesNotUpdate(t *testing.T) {
+	now := time.Now()
+
+	w := newTestWidgetFromYAML(t, `
+- type: videos
+  paused: true
+  channels:
+    - UCXuqSBlHAE6Xw-yeJA0Tunw
+`)
+
+	if w.requiresUpdate(&now) {
+		t.Fatal("Expected paused widget to not require an update")
+	}
+
+	if !strings.Contains(string(w.Render()), "This widget is paused.") {
+		t.Fatal("Expected paused widget to render the paused placeholder")
+	}
+
+	group := newTestWidgetFromYAML(t, `
+- type: group
+  paused: true
+  widgets:
+    - type: videos
+      channels:
+        - UCXuqSBlHAE6Xw-yeJA0Tunw
+`)
+
+	if group.requiresUpdate(&now) {
+		t.Fatal("Expected paused group to not require an update even if its widgets do")
+	}
+
+	for _, config := range []string{`
+- type: extension
+  paused: true
+  url: https://example.com
+`, `
+- type: html
+  paused: true
+  source: <p>hello</p>
+`} {
+		if rendered := string(newTestWidgetFromYAML(t, config).Render()); !strings.Contains(rendered, "This widget is paused.") {
+			t.Errorf("Expected paused widget to render the paused placeholder, got %q for:%s", rendered, config)
+		}
+	}
+
+	active := &countingTestWidget{}
+	paused := &countingTestWidget{}
+	active.withCacheDuration(time.Hour)
+	paused.withCacheDuration(time.Hour)
+	paused.Paused = true
+
+	container := containerWidgetBase{Widgets: widgets{active, paused}}
+	container._update(context.Background())
+
+	if active.updates.Load() != 1 {
+		t.Errorf("Expected active widget to be updated once, got %d", active.updates.Load())
+	}
+
+	if paused.updates.Load() != 0 {
+		t.Errorf("Expected paused widget to not be updated, got %d", paused.updates.Load())
+	}
+}
+
+type countingTestWidget struct {
+	widgetBase
+	updates atomic.Int32
+}
+
+func (widget *countingTestWidget) initialize() error     { return nil }
+func (widget *countingTestWidget) Render() template.HTML { return "" }
+func (widget *countingTestWidget) update(context.Context) {
+	widget.updates.Add(1)
+}
+
+type slowTestWidget struct {
+	widgetBase
+	delay time.Duration
+}
+
+func (widget *slowTestWidget) initialize() error     { return nil }
+func (widget *slowTestWidget) Render() template.HTML { return "" }
+func (widget *slowTestWidget) update(context.Context) {
+	time.Sleep(widget.delay)
+}
+
+func TestUpdateWidgetWithWatchdogLogsSlowUpdates(t *testing.T) {
+	var output bytes.Buffer
+	var mu sync.Mutex
+
+	previousLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&lockedWriter{w: &output, mu: &mu}, nil)))
+	defer slog.SetDefault(previousLogger)
+
+	threshold := durationField(20 * time.Millisecond)
+
+	fast := &slowTestWidget{widgetBase: widgetBase{Type: "fast", SlowUpdateThreshold: threshold}, delay: 0}
+	updateWidgetWithWatchdog(context.Background(), fast)
+
+	mu.Lock()
+	if output.Len() != 0 {
+		t.Fatalf("Did not expect a warning for a fast update, got %q", output.String())
+	}
+	mu.Unlock()
+
+	slow := &slowTestWidget{widgetBase: widgetBase{Type: "slow", SlowUpdateThreshold: threshold}, delay: 60 * time.Millisecond}
+	updateWidgetWithWatchdog(context.Background(), slow)
+
+	mu.Lock()
+	if !strings.Contains(output.String(), "Widget update is taking longer than expected") || !strings.Contains(output.String(), "type=slow") {
+		t.Fatalf("Expected a warning for the slow update, got %q", output.String())
+	}
+	output.Reset()
+	mu.Unlock()
+
+	// only the widgets of a page get watched, not the widgets within containers
+
+	group := &groupWidget{
+		widgetBase:          widgetBase{Type: "group", SlowUpdateThreshold: threshold},
+		containerWidgetBase: containerWidgetBase{Widgets: widgets{slow}},
+	}
+	slow.nextUpdate = time.Time{}
+	slow.withCacheDuration(time.Hour)
+	updateWidgetWithWatchdog(context.Background(), group)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if count := strings.Count(output.String(), "Widget update is taking longer than expected"); count != 1 {
+		t.Fatalf("Expected exactly one warning for a group with a slow widget, got %d: %q", count, output.String())
+	}
+
+	if strings.Contains(output.String(), "type=slow") {
+		t.Fatalf("Did not expect a warning for the widget within the group, got %q", output.String())
+	}
+}
+
+func TestSlowUpdateThresholdProperty(t *testing.T) {
+	w := newTestWidgetFromYAML(t, `
+- type: html
+  slow-update-threshold: 5s
+`)
+
+	if threshold := w.getSlowUpdateThreshold(); threshold != 5*time.Second {
+		t.Fatalf("Expected threshold of 5s, got %v", threshold)
+	}
+
+	w = newTestWidgetFromYAML(t, `
+- type: html
+`)
+
+	if threshold := w.getSlowUpdateThreshold(); threshold != defaultSlowWidgetUpdateThreshold {
+		t.Fatalf("Expected default threshold, got %v", threshold)
+	}
+}
+
+type lockedWriter struct {
+	w  *bytes.Buffer
+	mu *sync.Mutex
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+func TestWidgetOutsideOfVisibleRangeDoesNotUpdate(t *testing.T) {
+	now := time.Now()
+	outside := now.Add(2*time.Hour).Format("15:04") + "-" + now.Add(3*time.Hour).Format("15:04")
+
+	w := newTestWidgetFromYAML(t, `
+- type: videos
+  visible-between: "`+outside+`"
+  channels:
+    - UCXuqSBlHAE6Xw-yeJA0Tunw
+`)
+
+	if w.requiresUpdate(&now) {
+		t.Fatal("Expected widget outside of its visible range to not require an update")
+	}
+
+	if w.IsVisible() {
+		t.Fatal("Expected widget outside of its visible range to not be visible")
+	}
+}
+
+func TestPageContentOnlyRendersVisibleWidgets(t *testing.T) {
+	now := time.Now()
+	inside := now.Add(-time.Hour).Format("15:04") + "-" + now.Add(time.Hour).Format("15:04")
+	outside := now.Add(2*time.Hour).Format("15:04") + "-" + now.Add(3*time.Hour).Format("15:04")
+
+	config, err := newConfigFromYAML([]byte(`
+pages:
+  - name: Home
+    columns:
+      - size: full
+        widgets:
+          - type: bookmarks
+            title: Visible bookmarks
+            visible-between: "` + inside + `"
+            groups:
+              - links:
+                  - title: Example
+                    url: https://example.com
+          - type: bookmarks
+            title: Hidden bookmarks
+            visible-between: "` + outside + `"
+            groups:
+              - links:
+                  - title: Example
+                    url: https://example.com
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	page := &config.Pages[0]
+	hidden := page.Columns[0].Widgets[1]
+
+	// the HTML is cached when the widget gets initialized, so it's the page that has to leave it out
+	if !strings.Contains(string(hidden.Render()), "Hidden bookmarks") {
+		t.Fatal("Expected bookmarks widget to have cached its HTML")
+	}
+
+	var buffer bytes.Buffer
+	if err := pageContentTemplate.Execute(&buffer, templateData{Page: page}); err != nil {
+		t.Fatalf("Failed to render page content: %v", err)
+	}
+
+	if !strings.Contains(buffer.String(), "Visible bookmarks") {
+		t.Error("Expected widget inside of its visible range to be rendered")
+	}
+
+	if strings.Contains(buffer.String(), "Hidden bookmarks") {
+		t.Error("Expected widget outside of its visible range to not be rendered")
+	}
+}
+
+func TestGroupRejectsVisibleBetweenOnChildren(t *testing.T) {
+	var w widgets
+	if err := yaml.Unmarshal([]byte(`
+- type: group
+  widgets:
+    - type: html
+      visible-between: "09:00-17:00"
+      source: <p>hello</p>
+`), &w); err != nil {
+		t.Fatalf("Failed to parse widget: %v", err)
+	}
+
+	if err := w[0].initialize(); err == nil {
+		t.Fatal("Expected group with a visible-between child to fail to initialize")
+	}
+}